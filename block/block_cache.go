@@ -0,0 +1,27 @@
+package block
+
+import "sync"
+
+// BlockCache tracks which block hashes the manager has already observed
+// included in the DA layer.
+type BlockCache struct {
+	mtx        sync.RWMutex
+	daIncluded map[string]bool
+}
+
+// NewBlockCache returns an empty BlockCache.
+func NewBlockCache() *BlockCache {
+	return &BlockCache{daIncluded: make(map[string]bool)}
+}
+
+func (bc *BlockCache) setDAIncluded(hash string) {
+	bc.mtx.Lock()
+	defer bc.mtx.Unlock()
+	bc.daIncluded[hash] = true
+}
+
+func (bc *BlockCache) isDAIncluded(hash string) bool {
+	bc.mtx.RLock()
+	defer bc.mtx.RUnlock()
+	return bc.daIncluded[hash]
+}
@@ -0,0 +1,279 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	cmlog "github.com/cometbft/cometbft/libs/log"
+	cmtypes "github.com/cometbft/cometbft/types"
+	goDA "github.com/rollkit/go-da"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// daSubmitOutcomeWindow is how many of the most recent DA submission
+// outcomes recordDASubmitOutcome remembers, to decide whether the gas price
+// should decay: it only decays once every outcome in the window was a
+// success, so one rejection amid a run of successes doesn't immediately
+// start eroding the price that's currently getting blobs included.
+const daSubmitOutcomeWindow = 5
+
+// maxTxBytesOverhead is a conservative estimate of the header and commit
+// bytes that accompany a block's tx data on the wire, reserved out of the
+// consensus-param block size limit so PrepareProposal never asks the app
+// for more tx bytes than will actually fit in the block.
+const maxTxBytesOverhead = 1024
+
+// Manager runs candidate and received blocks through the application's
+// proposal hooks and submits pending blocks to the DA layer.
+type Manager struct {
+	dalc     *da.DAClient
+	store    *store.Store
+	executor *state.Executor
+
+	lastState types.State
+
+	pendingBlocks *PendingBlocks
+	blockCache    *BlockCache
+
+	// daConfig governs the adaptive gas price submitToDA escalates and
+	// decays, and daGasPrice is that price's current value, carried across
+	// calls to submitBlocksToDA. daOutcomes is the trailing window of
+	// recent submission outcomes recordDASubmitOutcome uses to decide when
+	// it's safe to decay daGasPrice back down.
+	daConfig   da.DAConfig
+	daGasPrice float64
+	daOutcomes []bool
+
+	logger cmlog.Logger
+}
+
+// maxProposalTxBytes returns the maximum number of tx bytes that fit in a
+// block built on top of st, after reserving room for the header and commit.
+func maxProposalTxBytes(st types.State) int64 {
+	maxBytes := st.ConsensusParams.Block.MaxBytes - maxTxBytesOverhead
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	return maxBytes
+}
+
+// prepareProposalTxs runs the candidate tx list through the application's
+// ABCI++ PrepareProposal hook (if enabled), returning the list - reordered
+// or filtered by the app - that should be sealed into the block at height.
+func (m *Manager) prepareProposalTxs(ctx context.Context, height uint64, txs types.Txs) (types.Txs, error) {
+	if m.executor == nil {
+		return txs, nil
+	}
+	return m.executor.PrepareProposal(ctx, height, maxProposalTxBytes(m.lastState), txs)
+}
+
+// validateProposal runs block through the application's ABCI++
+// ProcessProposal hook (if enabled) before it is applied, rejecting it if
+// the app votes REJECT.
+func (m *Manager) validateProposal(ctx context.Context, block *types.Block) error {
+	if m.executor == nil {
+		return nil
+	}
+	return m.executor.ProcessProposal(ctx, block)
+}
+
+// getInitialState returns the State the manager should start from: the state
+// already persisted in store, or a freshly initialized one derived from
+// genesis if none has been stored yet.
+func getInitialState(s *store.Store, genesis *cmtypes.GenesisDoc) (types.State, error) {
+	state, err := s.GetState(context.Background())
+	if errors.Is(err, store.ErrNoStateFound) {
+		return types.State{
+			ChainID:         genesis.ChainID,
+			InitialHeight:   uint64(genesis.InitialHeight),
+			LastBlockHeight: uint64(genesis.InitialHeight) - 1,
+			Version:         types.Version{Block: types.InitStateVersion.Consensus.Block, App: types.InitStateVersion.Consensus.App},
+		}, nil
+	}
+	if err != nil {
+		return types.State{}, err
+	}
+
+	if uint64(genesis.InitialHeight) > state.LastBlockHeight+1 {
+		return types.State{}, fmt.Errorf(
+			"genesis.InitialHeight (%d) is greater than last stored state's LastBlockHeight (%d)",
+			genesis.InitialHeight, state.LastBlockHeight,
+		)
+	}
+	return state, nil
+}
+
+// IsDAIncluded returns true if the block with the given hash has been seen
+// included in the DA layer.
+func (m *Manager) IsDAIncluded(hash types.Hash) bool {
+	return m.blockCache.isDAIncluded(hash.String())
+}
+
+// submitBlocksToDA submits all pending blocks to the DA layer, one blob per
+// block, batching consecutive blocks into as few submissions as possible
+// while keeping each submission's total size under the DA's MaxBlobSize. A block whose serialized size alone exceeds MaxBlobSize
+// is split into ordered shards (see da.SplitIntoShards) and submitted shard
+// by shard, so a single oversized block no longer blocks forward progress
+// for the rest of the chain.
+func (m *Manager) submitBlocksToDA(ctx context.Context) error {
+	maxBlobSize, err := m.dalc.DA.MaxBlobSize(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query DA max blob size: %w", err)
+	}
+
+	blocks := m.pendingBlocks.getPendingBlocks()
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].SignedHeader.Height() < blocks[j].SignedHeader.Height()
+	})
+
+	for len(blocks) > 0 {
+		var (
+			batch     [][]byte
+			batchSize uint64
+			submitted []*types.Block
+		)
+
+		for len(blocks) > 0 {
+			block := blocks[0]
+			blob, err := block.MarshalBinary()
+			if err != nil {
+				return fmt.Errorf("failed to marshal block at height %d: %w", block.SignedHeader.Height(), err)
+			}
+
+			if uint64(len(blob)) > maxBlobSize {
+				if len(batch) > 0 {
+					// flush the batch collected so far before handling the
+					// oversized block on its own
+					break
+				}
+				if err := m.submitBlockShards(ctx, block, blob, maxBlobSize); err != nil {
+					return err
+				}
+				blocks = blocks[1:]
+				continue
+			}
+
+			if batchSize+uint64(len(blob)) > maxBlobSize {
+				break
+			}
+
+			batch = append(batch, blob)
+			batchSize += uint64(len(blob))
+			submitted = append(submitted, block)
+			blocks = blocks[1:]
+		}
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		if _, err := m.submitToDA(ctx, batch); err != nil {
+			return fmt.Errorf("failed to submit blocks to DA: %w", err)
+		}
+
+		for _, block := range submitted {
+			m.pendingBlocks.removePendingBlock(block.SignedHeader.Height())
+			m.blockCache.setDAIncluded(block.SignedHeader.Hash().String())
+		}
+	}
+
+	return nil
+}
+
+// submitBlockShards splits blob into shards that each fit under maxBlobSize
+// and submits them one at a time, only dropping block from the pending set
+// once every shard has been acknowledged.
+func (m *Manager) submitBlockShards(ctx context.Context, block *types.Block, blob []byte, maxBlobSize uint64) error {
+	height := block.SignedHeader.Height()
+
+	shards, err := da.SplitIntoShards(height, blob, maxBlobSize)
+	if err != nil {
+		return fmt.Errorf("failed to split block at height %d into shards: %w", height, err)
+	}
+
+	m.pendingBlocks.setShardCount(height, len(shards))
+	for _, shard := range shards {
+		if _, err := m.submitToDA(ctx, [][]byte{shard}); err != nil {
+			return fmt.Errorf("failed to submit shard for block at height %d: %w", height, err)
+		}
+		if m.pendingBlocks.ackShard(height) {
+			m.pendingBlocks.removePendingBlock(height)
+			m.blockCache.setDAIncluded(block.SignedHeader.Hash().String())
+		}
+	}
+	return nil
+}
+
+// submitToDA submits blobs to the DA layer, making up to
+// daConfig.MaxSubmitAttempts attempts in total at an escalating gas price
+// while the DA layer rejects the submission for a transient reason (see
+// isRetryableDASubmitError); a non-retryable error is returned at once.
+// Every successful or retryably rejected attempt feeds
+// recordDASubmitOutcome, so a run of rejections keeps the price elevated
+// for subsequent calls to submitBlocksToDA and a run of successes lets it
+// decay back down.
+func (m *Manager) submitToDA(ctx context.Context, blobs [][]byte) ([]goDA.ID, error) {
+	var lastErr error
+	for attempt := 0; attempt < m.daConfig.MaxSubmitAttempts; attempt++ {
+		ids, err := m.dalc.Submit(ctx, blobs, m.daGasPrice)
+		if err == nil {
+			m.recordDASubmitOutcome(true)
+			return ids, nil
+		}
+		if !isRetryableDASubmitError(err) {
+			return nil, err
+		}
+		lastErr = err
+		m.recordDASubmitOutcome(false)
+	}
+	return nil, fmt.Errorf("rejected after %d attempts at gas price %.4f: %w", m.daConfig.MaxSubmitAttempts, m.daGasPrice, lastErr)
+}
+
+// isRetryableDASubmitError reports whether err is a transient DA submission
+// rejection worth retrying at a higher gas price, as opposed to a permanent
+// failure (e.g. a malformed blob) that no gas price will fix.
+func isRetryableDASubmitError(err error) bool {
+	return errors.Is(err, da.ErrTxTimedout) ||
+		errors.Is(err, da.ErrTxAlreadyInMempool) ||
+		errors.Is(err, da.ErrTxIncorrectAccountSequence)
+}
+
+// recordDASubmitOutcome updates the adaptive gas price after one submission
+// attempt: a failure escalates it by daConfig.GasPriceMultiplier, capped at
+// daConfig.GasPriceMax; a success only decays it back toward
+// daConfig.GasPriceInitial once the last daSubmitOutcomeWindow attempts
+// were all successes, so the price doesn't start eroding again before it's
+// clear the DA layer is reliably accepting it.
+func (m *Manager) recordDASubmitOutcome(ok bool) {
+	m.daOutcomes = append(m.daOutcomes, ok)
+	if len(m.daOutcomes) > daSubmitOutcomeWindow {
+		m.daOutcomes = m.daOutcomes[len(m.daOutcomes)-daSubmitOutcomeWindow:]
+	}
+
+	if !ok {
+		m.daGasPrice *= m.daConfig.GasPriceMultiplier
+		if m.daGasPrice > m.daConfig.GasPriceMax {
+			m.daGasPrice = m.daConfig.GasPriceMax
+		}
+		return
+	}
+
+	if len(m.daOutcomes) < daSubmitOutcomeWindow {
+		return
+	}
+	for _, o := range m.daOutcomes {
+		if !o {
+			return
+		}
+	}
+	m.daGasPrice /= m.daConfig.GasPriceMultiplier
+	if m.daGasPrice < m.daConfig.GasPriceInitial {
+		m.daGasPrice = m.daConfig.GasPriceInitial
+	}
+}
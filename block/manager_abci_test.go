@@ -0,0 +1,84 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/types"
+	"github.com/rollkit/rollkit/types/testfactory"
+)
+
+// reorderingApp is a fake ABCI++ application that reverses the tx order it's
+// handed by PrepareProposal, and rejects any ProcessProposal whose first tx
+// is the sentinel value below.
+type reorderingApp struct {
+	rejectFirstTx []byte
+}
+
+func (a *reorderingApp) PrepareProposal(_ context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
+	reordered := make([][]byte, len(req.Txs))
+	for i, tx := range req.Txs {
+		reordered[len(req.Txs)-1-i] = tx
+	}
+	return &abci.ResponsePrepareProposal{Txs: reordered}, nil
+}
+
+func (a *reorderingApp) ProcessProposal(_ context.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
+	if len(req.Txs) > 0 && string(req.Txs[0]) == string(a.rejectFirstTx) {
+		return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
+	}
+	return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}, nil
+}
+
+func TestPrepareProposalPreservesAppReordering(t *testing.T) {
+	require := require.New(t)
+
+	app := &reorderingApp{}
+	m := &Manager{executor: state.NewExecutor(app, "myChain", true)}
+
+	txs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}
+	got, err := m.prepareProposalTxs(context.Background(), 1, txs)
+	require.NoError(err)
+	require.Equal(types.Txs{types.Tx("c"), types.Tx("b"), types.Tx("a")}, got)
+}
+
+func TestPrepareProposalPassthroughWhenDisabled(t *testing.T) {
+	require := require.New(t)
+
+	app := &reorderingApp{}
+	m := &Manager{executor: state.NewExecutor(app, "myChain", false)}
+
+	txs := types.Txs{types.Tx("a"), types.Tx("b")}
+	got, err := m.prepareProposalTxs(context.Background(), 1, txs)
+	require.NoError(err)
+	require.Equal(txs, got)
+}
+
+func TestValidateProposalRejectsAppVote(t *testing.T) {
+	require := require.New(t)
+
+	app := &reorderingApp{rejectFirstTx: []byte("bad")}
+	m := &Manager{executor: state.NewExecutor(app, "myChain", true)}
+
+	block := testfactory.New(1, "myChain").Block(1)
+	block.Data.Txs = types.Txs{types.Tx("bad")}
+
+	err := m.validateProposal(context.Background(), block)
+	require.ErrorIs(err, state.ErrProposalRejected)
+}
+
+func TestValidateProposalAcceptsAppVote(t *testing.T) {
+	require := require.New(t)
+
+	app := &reorderingApp{rejectFirstTx: []byte("bad")}
+	m := &Manager{executor: state.NewExecutor(app, "myChain", true)}
+
+	block := testfactory.New(1, "myChain").Block(1)
+	block.Data.Txs = types.Txs{types.Tx("good")}
+
+	require.NoError(m.validateProposal(context.Background(), block))
+}
@@ -0,0 +1,105 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	goDA "github.com/rollkit/go-da"
+	goDATest "github.com/rollkit/go-da/test"
+
+	"github.com/rollkit/rollkit/da"
+	test "github.com/rollkit/rollkit/test/log"
+	"github.com/rollkit/rollkit/types/testfactory"
+)
+
+// scriptableDA wraps the go-da test double with a scripted sequence of
+// Submit outcomes, so tests can force a fixed number of rejections before
+// letting a submission through and assert on the gas price each attempt
+// was made at.
+type scriptableDA struct {
+	goDA.DA
+
+	mtx      sync.Mutex
+	failures []error // failures[i] is returned by the i'th Submit call instead of delegating
+	prices   []float64
+}
+
+func newScriptableDA(failures ...error) *scriptableDA {
+	return &scriptableDA{DA: goDATest.NewDummyDA(), failures: failures}
+}
+
+func (s *scriptableDA) Submit(ctx context.Context, blobs []goDA.Blob, gasPrice float64, namespace goDA.Namespace) ([]goDA.ID, error) {
+	s.mtx.Lock()
+	attempt := len(s.prices)
+	s.prices = append(s.prices, gasPrice)
+	var failure error
+	if attempt < len(s.failures) {
+		failure = s.failures[attempt]
+	}
+	s.mtx.Unlock()
+
+	if failure != nil {
+		return nil, failure
+	}
+	return s.DA.Submit(ctx, blobs, gasPrice, namespace)
+}
+
+func TestSubmitToDAEscalatesGasPriceOnRetry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	logger := test.NewFileLoggerCustom(t, test.TempLogFileName(t, t.Name()))
+	daConfig := da.DefaultDAConfig()
+	scripted := newScriptableDA(da.ErrTxTimedout, da.ErrTxAlreadyInMempool)
+	m := &Manager{
+		dalc:          &da.DAClient{DA: scripted, Logger: logger},
+		blockCache:    NewBlockCache(),
+		pendingBlocks: NewPendingBlocks(),
+		daConfig:      daConfig,
+		daGasPrice:    daConfig.GasPriceInitial,
+		logger:        logger,
+	}
+
+	f := testfactory.New(1, "myChain")
+	block := f.Block(1, testfactory.WithTxs(1))
+	m.pendingBlocks.addPendingBlock(block)
+
+	require.NoError(m.submitBlocksToDA(ctx))
+	require.Empty(m.pendingBlocks.getPendingBlocks(), "block should drain once submission succeeds")
+
+	require.Len(scripted.prices, 3, "expected two rejected attempts and one that succeeded")
+	require.Equal(daConfig.GasPriceInitial, scripted.prices[0])
+	require.Equal(daConfig.GasPriceInitial*daConfig.GasPriceMultiplier, scripted.prices[1])
+	require.Equal(daConfig.GasPriceInitial*daConfig.GasPriceMultiplier*daConfig.GasPriceMultiplier, scripted.prices[2])
+	require.Equal(scripted.prices[2], m.daGasPrice, "gas price should stay elevated after the run of rejections")
+}
+
+func TestSubmitToDAGivesUpAfterMaxAttempts(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	logger := test.NewFileLoggerCustom(t, test.TempLogFileName(t, t.Name()))
+	daConfig := da.DefaultDAConfig()
+	daConfig.MaxSubmitAttempts = 2
+	scripted := newScriptableDA(da.ErrTxTimedout, da.ErrTxTimedout, da.ErrTxTimedout)
+	m := &Manager{
+		dalc:          &da.DAClient{DA: scripted, Logger: logger},
+		blockCache:    NewBlockCache(),
+		pendingBlocks: NewPendingBlocks(),
+		daConfig:      daConfig,
+		daGasPrice:    daConfig.GasPriceInitial,
+		logger:        logger,
+	}
+
+	f := testfactory.New(1, "myChain")
+	block := f.Block(1, testfactory.WithTxs(1))
+	m.pendingBlocks.addPendingBlock(block)
+
+	err := m.submitBlocksToDA(ctx)
+	require.Error(err)
+	require.Len(m.pendingBlocks.getPendingBlocks(), 1, "block should stay pending when every attempt is rejected")
+	require.Len(scripted.prices, 2, "should stop retrying once MaxSubmitAttempts is reached")
+}
@@ -14,33 +14,22 @@ import (
 	"github.com/rollkit/rollkit/store"
 	test "github.com/rollkit/rollkit/test/log"
 	"github.com/rollkit/rollkit/types"
+	"github.com/rollkit/rollkit/types/testfactory"
 )
 
 // Returns a minimalistic block manager
 func getManager(t *testing.T) *Manager {
 	logger := test.NewFileLoggerCustom(t, test.TempLogFileName(t, t.Name()))
+	daConfig := da.DefaultDAConfig()
 	return &Manager{
-		dalc:       &da.DAClient{DA: goDATest.NewDummyDA(), GasPrice: -1, Logger: logger},
+		dalc:       &da.DAClient{DA: goDATest.NewDummyDA(), Logger: logger},
 		blockCache: NewBlockCache(),
+		daConfig:   daConfig,
+		daGasPrice: daConfig.GasPriceInitial,
 		logger:     logger,
 	}
 }
 
-// getBlockBiggerThan generates a block with the given height bigger than the specified limit.
-func getBlockBiggerThan(blockHeight, limit uint64) (*types.Block, error) {
-	for numTxs := 0; ; numTxs += 100 {
-		block := types.GetRandomBlock(blockHeight, numTxs)
-		blob, err := block.MarshalBinary()
-		if err != nil {
-			return nil, err
-		}
-
-		if uint64(len(blob)) > limit {
-			return block, nil
-		}
-	}
-}
-
 func TestInitialStateClean(t *testing.T) {
 	require := require.New(t)
 	genesisDoc, _ := types.GetGenesisWithPrivkey()
@@ -130,6 +119,7 @@ func TestSubmitBlocksToDA(t *testing.T) {
 	ctx := context.Background()
 
 	m := getManager(t)
+	f := testfactory.New(1, types.TestChainID)
 
 	maxDABlobSizeLimit, err := m.dalc.DA.MaxBlobSize(ctx)
 	require.NoError(err)
@@ -142,7 +132,7 @@ func TestSubmitBlocksToDA(t *testing.T) {
 	}{
 		{
 			name:                        "happy path, all blocks A, B, C combine to less than maxDABlobSize",
-			blocks:                      []*types.Block{types.GetRandomBlock(1, 5), types.GetRandomBlock(2, 5), types.GetRandomBlock(3, 5)},
+			blocks:                      []*types.Block{f.Block(1, testfactory.WithTxs(5)), f.Block(2, testfactory.WithTxs(5)), f.Block(3, testfactory.WithTxs(5))},
 			isErrExpected:               false,
 			expectedPendingBlocksLength: 0,
 		},
@@ -151,15 +141,15 @@ func TestSubmitBlocksToDA(t *testing.T) {
 			blocks: func() []*types.Block {
 				// Find three blocks where two of them are under blob size limit
 				// but adding the third one exceeds the blob size limit
-				block1 := types.GetRandomBlock(1, 100)
+				block1 := f.Block(1, testfactory.WithTxs(100))
 				blob1, err := block1.MarshalBinary()
 				require.NoError(err)
 
-				block2 := types.GetRandomBlock(2, 100)
+				block2 := f.Block(2, testfactory.WithTxs(100))
 				blob2, err := block2.MarshalBinary()
 				require.NoError(err)
 
-				block3, err := getBlockBiggerThan(3, maxDABlobSizeLimit-uint64(len(blob1)+len(blob2)))
+				block3, err := f.BlockBiggerThan(3, maxDABlobSizeLimit-uint64(len(blob1)+len(blob2)))
 				require.NoError(err)
 
 				return []*types.Block{block1, block2, block3}
@@ -168,19 +158,19 @@ func TestSubmitBlocksToDA(t *testing.T) {
 			expectedPendingBlocksLength: 0,
 		},
 		{
-			name: "A and B are submitted successfully but C is too big on its own, so C never gets submitted",
+			name: "A and B are submitted together and C, which is too big on its own, is split into shards and submitted too",
 			blocks: func() []*types.Block {
 				numBlocks, numTxs := 3, 5
 				blocks := make([]*types.Block, numBlocks)
 				for i := 0; i < numBlocks-1; i++ {
-					blocks[i] = types.GetRandomBlock(uint64(i+1), numTxs)
+					blocks[i] = f.Block(uint64(i+1), testfactory.WithTxs(numTxs))
 				}
-				blocks[2], err = getBlockBiggerThan(3, maxDABlobSizeLimit)
+				blocks[2], err = f.BlockBiggerThan(3, maxDABlobSizeLimit)
 				require.NoError(err)
 				return blocks
 			}(),
-			isErrExpected:               true,
-			expectedPendingBlocksLength: 1,
+			isErrExpected:               false,
+			expectedPendingBlocksLength: 0,
 		},
 	}
 
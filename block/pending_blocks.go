@@ -0,0 +1,79 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// PendingBlocks tracks blocks that have been produced locally but not yet
+// fully included in the DA layer.
+type PendingBlocks struct {
+	mtx    sync.RWMutex
+	blocks map[uint64]*types.Block
+
+	// shardsRemaining counts, for a block whose blob was too big to fit in a
+	// single DA blob and had to be split (see da.SplitIntoShards), how many
+	// of its shards are still unacknowledged. A block with an entry here is
+	// only dropped once the count reaches zero, so a failure partway through
+	// submitting its shards leaves it pending rather than silently lost.
+	shardsRemaining map[uint64]int
+}
+
+// NewPendingBlocks returns an empty PendingBlocks set.
+func NewPendingBlocks() *PendingBlocks {
+	return &PendingBlocks{
+		blocks:          make(map[uint64]*types.Block),
+		shardsRemaining: make(map[uint64]int),
+	}
+}
+
+func (pb *PendingBlocks) addPendingBlock(block *types.Block) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.blocks[block.SignedHeader.Height()] = block
+}
+
+func (pb *PendingBlocks) getPendingBlocks() []*types.Block {
+	pb.mtx.RLock()
+	defer pb.mtx.RUnlock()
+	blocks := make([]*types.Block, 0, len(pb.blocks))
+	for _, b := range pb.blocks {
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+func (pb *PendingBlocks) removePendingBlock(height uint64) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	delete(pb.blocks, height)
+	delete(pb.shardsRemaining, height)
+}
+
+// setShardCount records that the block at height was split into count
+// shards, none of which have been acknowledged yet.
+func (pb *PendingBlocks) setShardCount(height uint64, count int) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.shardsRemaining[height] = count
+}
+
+// ackShard records that one shard of the block at height was submitted
+// successfully. It returns true once every shard for that height has been
+// acknowledged, meaning the block is safe to drop from the pending set.
+func (pb *PendingBlocks) ackShard(height uint64) bool {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	remaining, ok := pb.shardsRemaining[height]
+	if !ok {
+		return true
+	}
+	remaining--
+	if remaining <= 0 {
+		delete(pb.shardsRemaining, height)
+		return true
+	}
+	pb.shardsRemaining[height] = remaining
+	return false
+}
@@ -0,0 +1,26 @@
+package da
+
+// DAConfig tunes the adaptive gas price Manager uses when resubmitting
+// blobs the DA layer rejected: GasPriceInitial is where the price starts
+// (and what it decays back toward after sustained successes),
+// GasPriceMultiplier is the factor escalation multiplies by on each
+// rejected attempt, GasPriceMax is the ceiling escalation won't exceed, and
+// MaxSubmitAttempts is how many attempts a single submission gets before
+// the caller gives up and leaves the blobs pending.
+type DAConfig struct {
+	GasPriceInitial    float64
+	GasPriceMultiplier float64
+	GasPriceMax        float64
+	MaxSubmitAttempts  int
+}
+
+// DefaultDAConfig returns the gas price behavior rollkit submits blobs with
+// when the caller hasn't overridden it.
+func DefaultDAConfig() DAConfig {
+	return DAConfig{
+		GasPriceInitial:    1,
+		GasPriceMultiplier: 1.3,
+		GasPriceMax:        100,
+		MaxSubmitAttempts:  5,
+	}
+}
@@ -0,0 +1,38 @@
+package da
+
+import (
+	"context"
+	"errors"
+
+	cmlog "github.com/cometbft/cometbft/libs/log"
+	goDA "github.com/rollkit/go-da"
+)
+
+// DAClient wraps a go-da DA implementation with the namespace rollkit
+// submits blobs under. Gas price is no longer fixed on the client: callers
+// pass the price for each submission, so Manager's adaptive controller can
+// escalate or decay it across retries (see block.Manager.submitToDA).
+type DAClient struct {
+	DA        goDA.DA
+	Namespace goDA.Namespace
+	Logger    cmlog.Logger
+}
+
+// Retryable DA submission rejections: the blob itself was fine, but the
+// node's mempool declined it for reasons a higher gas price (or a retry
+// once the mempool has moved on) can resolve.
+var (
+	ErrTxTimedout                 = errors.New("tx timed out waiting for inclusion")
+	ErrTxAlreadyInMempool         = errors.New("tx already in mempool")
+	ErrTxIncorrectAccountSequence = errors.New("incorrect account sequence")
+)
+
+// Submit submits blobs to the DA layer under the client's configured
+// namespace, at the given gas price.
+func (dalc *DAClient) Submit(ctx context.Context, blobs [][]byte, gasPrice float64) ([]goDA.ID, error) {
+	daBlobs := make([]goDA.Blob, len(blobs))
+	for i, b := range blobs {
+		daBlobs[i] = goDA.Blob(b)
+	}
+	return dalc.DA.Submit(ctx, daBlobs, gasPrice, dalc.Namespace)
+}
@@ -0,0 +1,27 @@
+package da
+
+// Retriever reassembles blocks that block.Manager had to split into shards
+// with SplitIntoShards, buffering partial shard sets until they're complete.
+//
+// NOTE: this tree has no block-sync/retrieval loop that calls Retriever
+// (block.Manager only submits blocks to the DA layer; it never reads them
+// back). IngestShard parses every blob it is given as a shard: shard blobs
+// carry no marker that tells them apart from an unsharded block blob, and
+// while a set is incomplete it returns a nil blob with complete=false.
+// shard_test.go tests the ShardBuffer that Retriever wraps, not Retriever
+// itself.
+type Retriever struct {
+	buffer *ShardBuffer
+}
+
+// NewRetriever returns a Retriever with an empty shard buffer.
+func NewRetriever() *Retriever {
+	return &Retriever{buffer: NewShardBuffer()}
+}
+
+// IngestShard feeds one shard blob retrieved from the DA layer into the
+// buffer. It returns the reassembled, hash-verified block bytes once every
+// shard for that block has arrived.
+func (r *Retriever) IngestShard(raw []byte) (blob []byte, complete bool, err error) {
+	return r.buffer.Add(raw)
+}
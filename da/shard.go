@@ -0,0 +1,139 @@
+package da
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// shardHeaderSize is the fixed-width header prefixed to every blob shard:
+// blockHeight(8) + shardIndex(4) + shardCount(4) + contentHash(32).
+const shardHeaderSize = 8 + 4 + 4 + sha256.Size
+
+// ShardHeader identifies one ordered piece of a block that was too large to
+// fit in a single DA blob.
+type ShardHeader struct {
+	BlockHeight uint64
+	ShardIndex  uint32
+	ShardCount  uint32
+	ContentHash [sha256.Size]byte
+}
+
+// MarshalBinary encodes the header into its fixed-width wire format.
+func (h ShardHeader) MarshalBinary() []byte {
+	buf := make([]byte, shardHeaderSize)
+	binary.BigEndian.PutUint64(buf[0:8], h.BlockHeight)
+	binary.BigEndian.PutUint32(buf[8:12], h.ShardIndex)
+	binary.BigEndian.PutUint32(buf[12:16], h.ShardCount)
+	copy(buf[16:16+sha256.Size], h.ContentHash[:])
+	return buf
+}
+
+// unmarshalShardHeader decodes a ShardHeader from the front of raw, and
+// returns the remaining payload bytes.
+func unmarshalShardHeader(raw []byte) (ShardHeader, []byte, error) {
+	if len(raw) < shardHeaderSize {
+		return ShardHeader{}, nil, fmt.Errorf("shard too small: got %d bytes, want at least %d", len(raw), shardHeaderSize)
+	}
+	var h ShardHeader
+	h.BlockHeight = binary.BigEndian.Uint64(raw[0:8])
+	h.ShardIndex = binary.BigEndian.Uint32(raw[8:12])
+	h.ShardCount = binary.BigEndian.Uint32(raw[12:16])
+	copy(h.ContentHash[:], raw[16:16+sha256.Size])
+	return h, raw[shardHeaderSize:], nil
+}
+
+// SplitIntoShards splits blob into ordered shards, each no larger than
+// maxShardSize including its header, so that a block too big to fit in a
+// single DA blob can still make forward progress. Shards must be reassembled
+// in order and verified against ContentHash before use; see ShardBuffer.
+func SplitIntoShards(blockHeight uint64, blob []byte, maxShardSize uint64) ([][]byte, error) {
+	if maxShardSize <= shardHeaderSize {
+		return nil, fmt.Errorf("max shard size %d is too small to fit a %d byte shard header", maxShardSize, shardHeaderSize)
+	}
+
+	contentHash := sha256.Sum256(blob)
+	payloadPerShard := maxShardSize - shardHeaderSize
+
+	shardCount := uint64(len(blob)) / payloadPerShard
+	if uint64(len(blob))%payloadPerShard != 0 || shardCount == 0 {
+		shardCount++
+	}
+
+	shards := make([][]byte, 0, shardCount)
+	for i := uint64(0); i < shardCount; i++ {
+		start := i * payloadPerShard
+		end := start + payloadPerShard
+		if end > uint64(len(blob)) {
+			end = uint64(len(blob))
+		}
+		header := ShardHeader{
+			BlockHeight: blockHeight,
+			ShardIndex:  uint32(i),
+			ShardCount:  uint32(shardCount),
+			ContentHash: contentHash,
+		}
+		shards = append(shards, append(header.MarshalBinary(), blob[start:end]...))
+	}
+	return shards, nil
+}
+
+// shardSetKey identifies the set of shards belonging to the same original
+// blob, keyed by height and content hash so shards from different heights or
+// a resubmitted (changed) block never mix.
+type shardSetKey struct {
+	height      uint64
+	contentHash [sha256.Size]byte
+}
+
+// ShardBuffer accumulates shards produced by SplitIntoShards until every
+// shard for a given (height, contentHash) has arrived, at which point the
+// original blob is reassembled and verified.
+type ShardBuffer struct {
+	sets map[shardSetKey]map[uint32][]byte
+}
+
+// NewShardBuffer returns an empty ShardBuffer.
+func NewShardBuffer() *ShardBuffer {
+	return &ShardBuffer{sets: make(map[shardSetKey]map[uint32][]byte)}
+}
+
+// Add records one shard. Once every shard for its (height, contentHash) set
+// has been seen, it reassembles the original blob, verifies it against
+// ContentHash, and returns it with complete=true. Until then it returns
+// complete=false.
+func (sb *ShardBuffer) Add(raw []byte) (blob []byte, complete bool, err error) {
+	header, payload, err := unmarshalShardHeader(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := shardSetKey{height: header.BlockHeight, contentHash: header.ContentHash}
+	set, ok := sb.sets[key]
+	if !ok {
+		set = make(map[uint32][]byte, header.ShardCount)
+		sb.sets[key] = set
+	}
+	set[header.ShardIndex] = payload
+
+	if uint32(len(set)) < header.ShardCount {
+		return nil, false, nil
+	}
+
+	reassembled := make([]byte, 0, uint32(len(set))*uint32(len(payload)))
+	for i := uint32(0); i < header.ShardCount; i++ {
+		part, ok := set[i]
+		if !ok {
+			return nil, false, nil
+		}
+		reassembled = append(reassembled, part...)
+	}
+	delete(sb.sets, key)
+
+	gotHash := sha256.Sum256(reassembled)
+	if !bytes.Equal(gotHash[:], header.ContentHash[:]) {
+		return nil, false, fmt.Errorf("reassembled blob for height %d failed content hash verification", header.BlockHeight)
+	}
+	return reassembled, true, nil
+}
@@ -0,0 +1,40 @@
+package da
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitAndReassembleShards(t *testing.T) {
+	require := require.New(t)
+
+	blob := make([]byte, 10_000)
+	for i := range blob {
+		blob[i] = byte(i)
+	}
+
+	shards, err := SplitIntoShards(42, blob, 1024)
+	require.NoError(err)
+	require.Greater(len(shards), 1)
+
+	buf := NewShardBuffer()
+	var reassembled []byte
+	for i, shard := range shards {
+		out, complete, err := buf.Add(shard)
+		require.NoError(err)
+		if i < len(shards)-1 {
+			require.False(complete)
+		} else {
+			require.True(complete)
+			reassembled = out
+		}
+	}
+
+	require.Equal(blob, reassembled)
+}
+
+func TestSplitIntoShardsRejectsTooSmallMaxSize(t *testing.T) {
+	_, err := SplitIntoShards(1, []byte("hello"), shardHeaderSize)
+	require.Error(t, err)
+}
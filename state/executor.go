@@ -0,0 +1,96 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrProposalRejected is returned when the application rejects a block via
+// ProcessProposal.
+var ErrProposalRejected = errors.New("application rejected block proposal")
+
+// ProposalApp is the subset of the ABCI++ application connection the
+// executor needs to run the PrepareProposal/ProcessProposal flow.
+type ProposalApp interface {
+	PrepareProposal(ctx context.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error)
+	ProcessProposal(ctx context.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error)
+}
+
+// Executor runs the ABCI++ proposal hooks of the application for blocks.
+type Executor struct {
+	app     ProposalApp
+	chainID string
+
+	// ABCIPPEnabled gates the ABCI++ PrepareProposal/ProcessProposal calls
+	// introduced upstream by CometBFT, so apps that still only implement the
+	// legacy BaseApplication continue to work unchanged.
+	ABCIPPEnabled bool
+}
+
+// NewExecutor returns an Executor that runs the proposal hooks against app.
+func NewExecutor(app ProposalApp, chainID string, abciPPEnabled bool) *Executor {
+	return &Executor{app: app, chainID: chainID, ABCIPPEnabled: abciPPEnabled}
+}
+
+// PrepareProposal asks the application to reorder or filter the candidate
+// tx list before it's sealed into a block at height, mirroring CometBFT's
+// ABCI++ PrepareProposal flow. When ABCI++ is disabled it returns txs
+// unchanged.
+func (e *Executor) PrepareProposal(ctx context.Context, height uint64, maxTxBytes int64, txs types.Txs) (types.Txs, error) {
+	if !e.ABCIPPEnabled {
+		return txs, nil
+	}
+
+	rawTxs := make([][]byte, len(txs))
+	for i, tx := range txs {
+		rawTxs[i] = tx
+	}
+
+	resp, err := e.app.PrepareProposal(ctx, &abci.RequestPrepareProposal{
+		MaxTxBytes: maxTxBytes,
+		Txs:        rawTxs,
+		Height:     int64(height),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare proposal at height %d: %w", height, err)
+	}
+
+	prepared := make(types.Txs, len(resp.Txs))
+	for i, tx := range resp.Txs {
+		prepared[i] = tx
+	}
+	return prepared, nil
+}
+
+// ProcessProposal asks the application to validate block before it is
+// applied, returning ErrProposalRejected if the app votes REJECT. When
+// ABCI++ is disabled it always succeeds.
+func (e *Executor) ProcessProposal(ctx context.Context, block *types.Block) error {
+	if !e.ABCIPPEnabled {
+		return nil
+	}
+
+	rawTxs := make([][]byte, len(block.Data.Txs))
+	for i, tx := range block.Data.Txs {
+		rawTxs[i] = tx
+	}
+
+	height := block.SignedHeader.Height()
+	resp, err := e.app.ProcessProposal(ctx, &abci.RequestProcessProposal{
+		Txs:    rawTxs,
+		Height: int64(height),
+		Hash:   block.SignedHeader.Hash(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to process proposal at height %d: %w", height, err)
+	}
+	if resp.Status == abci.ResponseProcessProposal_REJECT {
+		return fmt.Errorf("%w: height %d", ErrProposalRejected, height)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package state
+
+import (
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// UpdateState advances st past the block at header, applying the delayed
+// validator-set rotation CometBFT uses upstream: the validators that take
+// effect at header.Height()+1 are whatever was already staged in
+// st.NextValidators, and validatorUpdates returned by the app for this
+// block only get staged as the *next* NextValidators, so they don't take
+// effect until header.Height()+2.
+func UpdateState(st types.State, header *types.Header, validatorUpdates *cmtypes.ValidatorSet) types.State {
+	next := st
+	next.LastBlockHeight = header.Height()
+	next.Validators = st.NextValidators
+	if validatorUpdates != nil {
+		next.NextValidators = validatorUpdates
+	}
+	return next
+}
@@ -0,0 +1,55 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/types"
+	"github.com/rollkit/rollkit/types/testfactory"
+)
+
+// TestValidatorRotationDelayedByTwoHeights builds three consecutive blocks
+// where a validator-set rotation is announced at height 1 but, mirroring
+// CometBFT's rule that ResponseEndBlock updates at height H only take
+// effect at H+2, doesn't actually become the signing set until height 3.
+// Header linkage and commit verification must keep succeeding throughout.
+func TestValidatorRotationDelayedByTwoHeights(t *testing.T) {
+	require := require.New(t)
+
+	fA := testfactory.New(1, types.TestChainID)
+	fB := testfactory.New(2, types.TestChainID)
+	valSetA, privKeyA := fA.ValidatorSet(), fA.ProposerKey()
+	valSetB, privKeyB := fB.ValidatorSet(), fB.ProposerKey()
+
+	s0 := types.State{
+		ChainID:        types.TestChainID,
+		InitialHeight:  1,
+		Validators:     valSetA,
+		NextValidators: valSetA,
+	}
+
+	block1 := fA.Block(1, testfactory.WithTxs(2), testfactory.WithValidators(s0.Validators), testfactory.WithNextValidators(s0.NextValidators))
+
+	// EndBlock(1) returns validatorUpdates=valSetB: per the delay rule this
+	// only lands as s2.Validators, i.e. it governs block 3, not block 2.
+	s1 := state.UpdateState(s0, &block1.SignedHeader.Header, valSetB)
+	require.Equal(valSetA.Hash().String(), s1.Validators.Hash().String())
+	require.Equal(valSetB.Hash().String(), s1.NextValidators.Hash().String())
+
+	block2 := fA.NextBlock(block1, testfactory.WithTxs(2), testfactory.WithValidators(s1.Validators), testfactory.WithNextValidators(s1.NextValidators))
+	require.NoError(types.ValidateNextHeaderLink(&block1.SignedHeader.Header, &block2.SignedHeader.Header))
+	require.NoError(types.VerifyCommit(&block2.SignedHeader.Header, &block2.SignedHeader.Commit, valSetA))
+	require.Error(types.VerifyCommit(&block2.SignedHeader.Header, &block2.SignedHeader.Commit, valSetB),
+		"block2 is still signed under the pre-rotation set; verifying it against the rotated set must fail")
+
+	s2 := state.UpdateState(s1, &block2.SignedHeader.Header, nil)
+	require.Equal(valSetB.Hash().String(), s2.Validators.Hash().String(), "rotation should land exactly two blocks after being announced")
+
+	block3 := fB.NextBlock(block2, testfactory.WithTxs(2), testfactory.WithProposer(privKeyB), testfactory.WithValidators(s2.Validators), testfactory.WithNextValidators(s2.NextValidators))
+	require.NoError(types.ValidateNextHeaderLink(&block2.SignedHeader.Header, &block3.SignedHeader.Header))
+	require.NoError(types.VerifyCommit(&block3.SignedHeader.Header, &block3.SignedHeader.Commit, valSetB))
+	require.Error(types.VerifyCommit(&block3.SignedHeader.Header, &block3.SignedHeader.Commit, valSetA),
+		"block3 is signed under the rotated set; verifying it against the old set must fail")
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrNoStateFound is returned when no state has been persisted yet.
+var ErrNoStateFound = errors.New("no state found")
+
+var stateKey = []byte("s")
+
+// KVStore is the minimal key/value interface Store is built on.
+type KVStore interface {
+	Get(ctx context.Context, key []byte) ([]byte, error)
+	Set(ctx context.Context, key, value []byte) error
+}
+
+// Store persists rollkit chain state on top of a KVStore.
+type Store struct {
+	db KVStore
+}
+
+// New creates a new Store backed by kv.
+func New(kv KVStore) *Store {
+	return &Store{db: kv}
+}
+
+// UpdateState persists the given state, overwriting whatever was stored before.
+func (s *Store) UpdateState(ctx context.Context, state types.State) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.db.Set(ctx, stateKey, b)
+}
+
+// GetState returns the most recently persisted state.
+func (s *Store) GetState(ctx context.Context) (types.State, error) {
+	b, err := s.db.Get(ctx, stateKey)
+	if err != nil {
+		return types.State{}, err
+	}
+	if b == nil {
+		return types.State{}, ErrNoStateFound
+	}
+	var state types.State
+	if err := json.Unmarshal(b, &state); err != nil {
+		return types.State{}, err
+	}
+	return state, nil
+}
+
+type inMemoryKVStore struct {
+	mtx  sync.RWMutex
+	data map[string][]byte
+}
+
+// NewDefaultInMemoryKVStore returns a KVStore backed by an in-memory map, used in tests.
+func NewDefaultInMemoryKVStore() (KVStore, error) {
+	return &inMemoryKVStore{data: make(map[string][]byte)}, nil
+}
+
+func (m *inMemoryKVStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.data[string(key)], nil
+}
+
+func (m *inMemoryKVStore) Set(_ context.Context, key, value []byte) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.data[string(key)] = value
+	return nil
+}
@@ -0,0 +1,30 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmlog "github.com/cometbft/cometbft/libs/log"
+)
+
+// TempLogFileName returns a unique log file path for test t under the OS temp dir.
+func TempLogFileName(t *testing.T, name string) string {
+	t.Helper()
+	return filepath.Join(t.TempDir(), fmt.Sprintf("%s.log", name))
+}
+
+// NewFileLoggerCustom returns a logger that writes to path, closing the file
+// once the test completes.
+func NewFileLoggerCustom(t *testing.T, path string) cmlog.Logger {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create log file %s: %v", path, err)
+	}
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+	return cmlog.NewTMLogger(f)
+}
@@ -0,0 +1,139 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/celestiaorg/go-header"
+	cmtypes "github.com/cometbft/cometbft/types"
+)
+
+// Hash is a 32-byte content hash used to identify headers, blocks and data.
+type Hash []byte
+
+// String returns the hex representation of the hash.
+func (h Hash) String() string {
+	return fmt.Sprintf("%X", []byte(h))
+}
+
+// Tx represents a raw transaction.
+type Tx []byte
+
+// Txs is a list of transactions.
+type Txs []Tx
+
+// Data defines the structured block data (currently just the tx list).
+type Data struct {
+	Txs Txs
+}
+
+// Hash returns the hash over the block data.
+func (d *Data) Hash() (header.Hash, error) {
+	b, err := json.Marshal(d.Txs)
+	if err != nil {
+		return nil, err
+	}
+	sum := hashBytes(b)
+	return sum, nil
+}
+
+// BaseHeader contains the fields shared by every header in the chain.
+type BaseHeader struct {
+	Height  uint64
+	Time    uint64
+	ChainID string
+}
+
+// Version captures the block and app protocol versions in effect for a header.
+type Version struct {
+	Block uint64
+	App   uint64
+}
+
+// Header defines the structure of a rollkit block header.
+type Header struct {
+	BaseHeader
+	Version Version
+
+	LastHeaderHash  header.Hash
+	LastCommitHash  header.Hash
+	DataHash        header.Hash
+	ConsensusHash   header.Hash
+	AppHash         header.Hash
+	LastResultsHash header.Hash
+
+	ProposerAddress []byte
+	ValidatorHash   header.Hash
+
+	// NextValidatorHash is the hash of the validator set active at this
+	// header's height+1. Because validator updates returned by
+	// ResponseEndBlock at this height only take effect starting at
+	// height+2 (see state.UpdateState), this hash usually still matches
+	// ValidatorHash - it only changes once a rotation staged two blocks
+	// earlier comes due.
+	NextValidatorHash header.Hash
+}
+
+// Height returns the height of the header.
+func (h *Header) Height() uint64 {
+	return h.BaseHeader.Height
+}
+
+// Hash returns the hash of the header.
+func (h *Header) Hash() header.Hash {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return nil
+	}
+	return hashBytes(b)
+}
+
+// MakeCometBFTVote returns the bytes that get signed over by validators when
+// committing this header: the header's hash.
+func (h *Header) MakeCometBFTVote() []byte {
+	return h.Hash()
+}
+
+// Signature is a single validator signature over a Commit.
+type Signature []byte
+
+// Commit contains the signatures that finalize a header.
+type Commit struct {
+	Signatures []Signature
+}
+
+// GetCommitHash returns the hash of the commit for the given header and proposer.
+func (c *Commit) GetCommitHash(h *Header, proposerAddress []byte) header.Hash {
+	b, err := json.Marshal(struct {
+		Sigs     []Signature
+		Header   header.Hash
+		Proposer []byte
+	}{c.Signatures, h.Hash(), proposerAddress})
+	if err != nil {
+		return nil
+	}
+	return hashBytes(b)
+}
+
+// SignedHeader couples a Header with the Commit and validator set that sign it.
+type SignedHeader struct {
+	Header
+	Commit     Commit
+	Validators *cmtypes.ValidatorSet
+}
+
+// Block is a full rollkit block: a signed header plus its data.
+type Block struct {
+	SignedHeader SignedHeader
+	Data         Data
+}
+
+// MarshalBinary serializes the block into a deterministic byte representation.
+func (b *Block) MarshalBinary() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+// UnmarshalBinary restores a block from bytes produced by MarshalBinary.
+func (b *Block) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, b)
+}
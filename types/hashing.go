@@ -0,0 +1,9 @@
+package types
+
+import "crypto/sha256"
+
+// hashBytes returns the sha256 digest of b.
+func hashBytes(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
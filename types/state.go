@@ -0,0 +1,44 @@
+package types
+
+import (
+	cmproto "github.com/cometbft/cometbft/proto/tendermint/version"
+	cmtypes "github.com/cometbft/cometbft/types"
+)
+
+// stateVersion mirrors CometBFT's state.Version, which nests the consensus
+// version under a Consensus field.
+type stateVersion struct {
+	Consensus cmproto.Consensus
+}
+
+// InitStateVersion is the version written into State when a chain is
+// initialized from genesis.
+var InitStateVersion = stateVersion{
+	Consensus: cmproto.Consensus{
+		Block: 11,
+		App:   0,
+	},
+}
+
+// State tracks the rollup's view of consensus-relevant chain state, mirroring
+// CometBFT's State but trimmed to what the centralized sequencer needs.
+type State struct {
+	ChainID         string
+	InitialHeight   uint64
+	LastBlockHeight uint64
+
+	Version Version
+
+	// Validators is the validator set active for the next block, at
+	// LastBlockHeight+1.
+	Validators *cmtypes.ValidatorSet
+	// NextValidators is the validator set staged for the block after that,
+	// at LastBlockHeight+2. Validator updates returned by the app only ever land
+	// here first, mirroring CometBFT's H -> H+2 activation delay.
+	NextValidators *cmtypes.ValidatorSet
+
+	ConsensusParams cmtypes.ConsensusParams
+
+	LastResultsHash []byte
+	AppHash         []byte
+}
@@ -0,0 +1,235 @@
+// Package testfactory builds deterministic, seeded block and header
+// fixtures for tests, modeled on Tendermint's internal/test/factory. Using a
+// seeded Factory instead of package-level math/rand keeps fixtures
+// reproducible across `go test -count=N` runs, which matters for tests like
+// the DA blob-size search that otherwise generate a new random block on
+// every iteration.
+package testfactory
+
+import (
+	"math/rand"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmtypes "github.com/cometbft/cometbft/types"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Factory generates blocks and headers from a seeded random source, a fixed
+// chain ID, and a default signing key.
+type Factory struct {
+	rand        *rand.Rand
+	chainID     string
+	proposerKey ed25519.PrivKey
+}
+
+// New returns a Factory seeded deterministically from seed, using chainID
+// and a proposer key derived from the same seed.
+func New(seed int64, chainID string) *Factory {
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+	secret := make([]byte, 32)
+	_, _ = r.Read(secret)
+
+	return &Factory{
+		rand:        r,
+		chainID:     chainID,
+		proposerKey: ed25519.GenPrivKeyFromSecret(secret),
+	}
+}
+
+// ProposerKey returns the factory's default signing key.
+func (f *Factory) ProposerKey() ed25519.PrivKey {
+	return f.proposerKey
+}
+
+// ValidatorSet returns the single-validator set for the factory's default
+// proposer key.
+func (f *Factory) ValidatorSet() *cmtypes.ValidatorSet {
+	return types.GetValidatorSet(f.proposerKey)
+}
+
+// options carries the resolved settings for a single Block/NextBlock call.
+type options struct {
+	nTxs           int
+	proposer       ed25519.PrivKey
+	appHash        []byte
+	validators     *cmtypes.ValidatorSet
+	nextValidators *cmtypes.ValidatorSet
+}
+
+// Option customizes a block or header produced by Factory.
+type Option func(*options)
+
+// WithTxs sets the number of random transactions a generated block carries.
+func WithTxs(n int) Option {
+	return func(o *options) { o.nTxs = n }
+}
+
+// WithProposer overrides the key that signs a generated block.
+func WithProposer(key ed25519.PrivKey) Option {
+	return func(o *options) { o.proposer = key }
+}
+
+// WithAppHash overrides the AppHash carried by a generated block.
+func WithAppHash(hash []byte) Option {
+	return func(o *options) { o.appHash = hash }
+}
+
+// WithValidators overrides the validator set active for a generated block.
+func WithValidators(valSet *cmtypes.ValidatorSet) Option {
+	return func(o *options) { o.validators = valSet }
+}
+
+// WithNextValidators overrides the validator set hashed into a generated
+// header's NextValidatorHash.
+func WithNextValidators(valSet *cmtypes.ValidatorSet) Option {
+	return func(o *options) { o.nextValidators = valSet }
+}
+
+func (f *Factory) resolve(opts []Option) options {
+	o := options{proposer: f.proposerKey}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (f *Factory) randomBytes(n int) []byte {
+	b := make([]byte, n)
+	_, _ = f.rand.Read(b)
+	return b
+}
+
+func (f *Factory) randomTx() types.Tx {
+	size := f.rand.Intn(100) + 100
+	return types.Tx(f.randomBytes(size))
+}
+
+func (f *Factory) randomTxs(n int) types.Txs {
+	if n == 0 {
+		return nil
+	}
+	txs := make(types.Txs, n)
+	for i := range txs {
+		txs[i] = f.randomTx()
+	}
+	return txs
+}
+
+// header returns a bare, chain-unaware header at height with deterministic
+// filler for the fields Block/NextBlock don't otherwise set.
+func (f *Factory) header(height uint64) types.Header {
+	return types.Header{
+		BaseHeader: types.BaseHeader{
+			Height:  height,
+			Time:    height * uint64(1_000_000_000), // deterministic, 1s per height
+			ChainID: f.chainID,
+		},
+		Version: types.Version{
+			Block: types.InitStateVersion.Consensus.Block,
+			App:   types.InitStateVersion.Consensus.App,
+		},
+		LastHeaderHash:  f.randomBytes(32),
+		LastCommitHash:  f.randomBytes(32),
+		ConsensusHash:   f.randomBytes(32),
+		LastResultsHash: f.randomBytes(32),
+	}
+}
+
+// Block returns a standalone block at height, with no parent to chain from.
+func (f *Factory) Block(height uint64, opts ...Option) *types.Block {
+	o := f.resolve(opts)
+
+	validators := o.validators
+	if validators == nil {
+		validators = types.GetValidatorSet(o.proposer)
+	}
+	nextValidators := o.nextValidators
+	if nextValidators == nil {
+		nextValidators = validators
+	}
+
+	block := &types.Block{Data: types.Data{Txs: f.randomTxs(o.nTxs)}}
+	dataHash, err := block.Data.Hash()
+	if err != nil {
+		panic(err)
+	}
+
+	header := f.header(height)
+	header.DataHash = dataHash
+	header.AppHash = o.appHash
+	header.ProposerAddress = validators.Proposer.Address.Bytes()
+	header.ValidatorHash = validators.Hash()
+	header.NextValidatorHash = nextValidators.Hash()
+
+	commit, err := types.GetCommit(header, o.proposer)
+	if err != nil {
+		panic(err)
+	}
+
+	block.SignedHeader = types.SignedHeader{Header: header, Commit: *commit, Validators: validators}
+	return block
+}
+
+// NextBlock returns a block at prev's height+1, correctly linked to it:
+// LastHeaderHash and LastCommitHash chain from prev. Unless overridden, the
+// block reuses prev's validator set, so its ValidatorHash matches prev's
+// declared NextValidatorHash only when that equals prev's own ValidatorHash.
+// Use WithValidators/WithNextValidators to simulate a validator-set rotation.
+func (f *Factory) NextBlock(prev *types.Block, opts ...Option) *types.Block {
+	o := f.resolve(opts)
+
+	validators := o.validators
+	if validators == nil {
+		validators = prev.SignedHeader.Validators
+	}
+	nextValidators := o.nextValidators
+	if nextValidators == nil {
+		nextValidators = validators
+	}
+	appHash := o.appHash
+	if appHash == nil {
+		appHash = prev.SignedHeader.AppHash
+	}
+
+	block := &types.Block{Data: types.Data{Txs: f.randomTxs(o.nTxs)}}
+	dataHash, err := block.Data.Hash()
+	if err != nil {
+		panic(err)
+	}
+
+	header := f.header(prev.SignedHeader.Height() + 1)
+	header.LastHeaderHash = prev.SignedHeader.Hash()
+	header.ProposerAddress = validators.Proposer.Address.Bytes()
+	header.DataHash = dataHash
+	header.AppHash = appHash
+	header.ValidatorHash = validators.Hash()
+	header.NextValidatorHash = nextValidators.Hash()
+	header.LastCommitHash = prev.SignedHeader.Commit.GetCommitHash(&header, prev.SignedHeader.ProposerAddress)
+
+	commit, err := types.GetCommit(header, o.proposer)
+	if err != nil {
+		panic(err)
+	}
+
+	block.SignedHeader = types.SignedHeader{Header: header, Commit: *commit, Validators: validators}
+	return block
+}
+
+// BlockBiggerThan generates a block at height whose serialized size exceeds
+// limit, growing the tx count geometrically (doubling) instead of linearly
+// so the search completes in a logarithmic, not linear, number of
+// iterations regardless of how large limit is.
+func (f *Factory) BlockBiggerThan(height uint64, limit uint64) (*types.Block, error) {
+	for numTxs := 1; ; numTxs *= 2 {
+		block := f.Block(height, WithTxs(numTxs))
+		blob, err := block.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(blob)) > limit {
+			return block, nil
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	cmtypes "github.com/cometbft/cometbft/types"
+)
+
+// ValidateNextHeaderLink checks that next correctly succeeds h: the heights
+// are consecutive, next links back to h's hash, and - mirroring CometBFT's
+// rule that validator updates returned by ResponseEndBlock at height H only
+// take effect starting at H+2 - next's ValidatorHash matches the
+// NextValidatorHash h itself declared, not h's own ValidatorHash.
+func ValidateNextHeaderLink(h, next *Header) error {
+	if next.Height() != h.Height()+1 {
+		return fmt.Errorf("expected header at height %d, got %d", h.Height()+1, next.Height())
+	}
+	if !bytes.Equal(next.LastHeaderHash, h.Hash()) {
+		return fmt.Errorf("header at height %d does not link to header at height %d", next.Height(), h.Height())
+	}
+	if !bytes.Equal(next.ValidatorHash, h.NextValidatorHash) {
+		return fmt.Errorf(
+			"header at height %d declares validator hash %X, want %X as announced by height %d's NextValidatorHash",
+			next.Height(), []byte(next.ValidatorHash), []byte(h.NextValidatorHash), h.Height(),
+		)
+	}
+	return nil
+}
+
+// VerifyCommit checks that commit carries a signature from header's
+// proposer, as recorded in valSet, over header itself - so a commit
+// produced against the wrong validator set during a rotation can't
+// silently slip through.
+func VerifyCommit(header *Header, commit *Commit, valSet *cmtypes.ValidatorSet) error {
+	if len(commit.Signatures) == 0 {
+		return fmt.Errorf("commit for header at height %d has no signatures", header.Height())
+	}
+	_, validator := valSet.GetByAddress(header.ProposerAddress)
+	if validator == nil {
+		return fmt.Errorf("commit for header at height %d: proposer %X is not in the given validator set", header.Height(), []byte(header.ProposerAddress))
+	}
+	if !validator.PubKey.VerifySignature(header.MakeCometBFTVote(), commit.Signatures[0]) {
+		return fmt.Errorf("commit for header at height %d carries an invalid signature from proposer %X", header.Height(), []byte(header.ProposerAddress))
+	}
+	return nil
+}